@@ -0,0 +1,322 @@
+package orchestration
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// EventType identifies what kind of state change an Event records.
+type EventType string
+
+const (
+	EventTypeTaskExecuted          EventType = "task_executed"
+	EventTypeHookVerdict           EventType = "hook_verdict"
+	EventTypeIntrospectionSnapshot EventType = "introspection_snapshot"
+	EventTypeDTEStatusRefreshed    EventType = "dte_status_refreshed"
+)
+
+// Event is a single immutable, content-hash-chained record in the
+// engine's event log.
+type Event struct {
+	Seq      uint64
+	Type     EventType
+	AgentID  string
+	Payload  json.RawMessage
+	PrevHash string
+	Hash     string
+}
+
+// EventLog is a pluggable append-only backend for Events. Implementations
+// must preserve append order and make Append durable before returning.
+type EventLog interface {
+	// Append writes a new event, assigning it the next sequence number
+	// and chaining its hash to the previous event's.
+	Append(ctx context.Context, eventType EventType, agentID string, payload interface{}) (Event, error)
+	// UpTo returns every event with Seq <= seq, in order, for deterministic
+	// prefix replay. seq == 0 returns the full log.
+	UpTo(ctx context.Context, seq uint64) ([]Event, error)
+	// Close releases any resources held by the backend.
+	Close() error
+}
+
+// hashEvent computes the content hash for an event given its predecessor's
+// hash, chaining the log into a tamper-evident sequence.
+func hashEvent(seq uint64, eventType EventType, agentID string, payload json.RawMessage, prevHash string) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte(eventType))
+	h.Write([]byte(agentID))
+	h.Write(payload)
+	var seqBuf [8]byte
+	binary.BigEndian.PutUint64(seqBuf[:], seq)
+	h.Write(seqBuf[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FileEventLog is an EventLog backed by a local file of length-prefixed
+// JSON records, one per Event.
+type FileEventLog struct {
+	mu       sync.Mutex
+	file     *os.File
+	events   []Event
+	subs     []chan Event
+}
+
+// OpenFileEventLog opens (creating if necessary) a length-prefixed JSON
+// event log at path and replays any existing records into memory.
+func OpenFileEventLog(path string) (*FileEventLog, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("orchestration: opening event log: %w", err)
+	}
+
+	log := &FileEventLog{file: f}
+	if err := log.loadExisting(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return log, nil
+}
+
+func (l *FileEventLog) loadExisting() error {
+	if _, err := l.file.Seek(0, 0); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(l.file)
+	for {
+		var length uint32
+		if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+			break
+		}
+		buf := make([]byte, length)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return fmt.Errorf("orchestration: truncated event log record: %w", err)
+		}
+		var ev Event
+		if err := json.Unmarshal(buf, &ev); err != nil {
+			return fmt.Errorf("orchestration: corrupt event log record: %w", err)
+		}
+		l.events = append(l.events, ev)
+	}
+	if _, err := l.file.Seek(0, 2); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Append implements EventLog.
+func (l *FileEventLog) Append(ctx context.Context, eventType EventType, agentID string, payload interface{}) (Event, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("orchestration: marshaling event payload: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seq := uint64(len(l.events)) + 1
+	prevHash := ""
+	if len(l.events) > 0 {
+		prevHash = l.events[len(l.events)-1].Hash
+	}
+
+	ev := Event{
+		Seq:      seq,
+		Type:     eventType,
+		AgentID:  agentID,
+		Payload:  raw,
+		PrevHash: prevHash,
+		Hash:     hashEvent(seq, eventType, agentID, raw, prevHash),
+	}
+
+	encoded, err := json.Marshal(ev)
+	if err != nil {
+		return Event{}, err
+	}
+
+	var lengthBuf [4]byte
+	binary.BigEndian.PutUint32(lengthBuf[:], uint32(len(encoded)))
+	if _, err := l.file.Write(lengthBuf[:]); err != nil {
+		return Event{}, err
+	}
+	if _, err := l.file.Write(encoded); err != nil {
+		return Event{}, err
+	}
+	if err := l.file.Sync(); err != nil {
+		return Event{}, err
+	}
+
+	l.events = append(l.events, ev)
+	for _, sub := range l.subs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+	return ev, nil
+}
+
+// UpTo implements EventLog.
+func (l *FileEventLog) UpTo(ctx context.Context, seq uint64) ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var result []Event
+	for _, ev := range l.events {
+		if seq == 0 || ev.Seq <= seq {
+			result = append(result, ev)
+		}
+	}
+	return result, nil
+}
+
+// Close implements EventLog.
+func (l *FileEventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, sub := range l.subs {
+		close(sub)
+	}
+	l.subs = nil
+	return l.file.Close()
+}
+
+// subscribe registers a channel that receives events appended from this
+// point forward. Append never blocks on it: an event is dropped for this
+// subscriber when its 32-event buffer is full.
+func (l *FileEventLog) subscribe() chan Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ch := make(chan Event, 32)
+	l.subs = append(l.subs, ch)
+	return ch
+}
+
+// unsubscribe removes ch from the log's subscriber list, so Append stops
+// selecting on it once the caller has stopped receiving.
+func (l *FileEventLog) unsubscribe(ch chan Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, sub := range l.subs {
+		if sub == ch {
+			l.subs = append(l.subs[:i], l.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetEventLog attaches an EventLog to the engine. Once attached,
+// ExecuteTask and RefreshDeepTreeEchoStatus append an Event for every
+// task execution and status refresh.
+func (e *Engine) SetEventLog(log EventLog) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.eventLog = log
+}
+
+// ReplayFrom reconstructs agent memory and Deep Tree Echo coherence and
+// resonance state by replaying every event with Seq <= seq, in order,
+// from the start of the log against a fresh in-memory engine. It does not
+// mutate the receiver. seq == 0 replays the entire log. Because every call
+// replays the same deterministic prefix, operators can bisect
+// identity-coherence regressions by calling ReplayFrom with successive
+// sequence numbers. Hook verdict events are not applied, so output that a
+// post-hook veto removed from an agent's memory is present again in the
+// replayed engine.
+func (e *Engine) ReplayFrom(ctx context.Context, seq uint64) (*Engine, error) {
+	e.mu.RLock()
+	log := e.eventLog
+	e.mu.RUnlock()
+	if log == nil {
+		return nil, fmt.Errorf("orchestration: no event log attached")
+	}
+
+	events, err := log.UpTo(ctx, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed := NewEngine(e.client)
+	if err := replayed.InitializeDeepTreeEcho(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, ev := range events {
+		agent, ok := replayed.agents[ev.AgentID]
+		if !ok {
+			agent = &Agent{ID: ev.AgentID, Name: ev.AgentID}
+			replayed.agents[ev.AgentID] = agent
+		}
+
+		switch ev.Type {
+		case EventTypeTaskExecuted:
+			var payload struct {
+				Output string `json:"output"`
+				Input  string `json:"input"`
+			}
+			if err := json.Unmarshal(ev.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("orchestration: replaying seq %d: %w", ev.Seq, err)
+			}
+			agent.State.Context = append(agent.State.Context, payload.Input)
+			agent.State.Memory = append(agent.State.Memory, payload.Output)
+			replayed.dte.ThoughtCount++
+		case EventTypeIntrospectionSnapshot:
+			replayed.dte.RecursiveDepth++
+		case EventTypeDTEStatusRefreshed:
+			if err := replayed.RefreshDeepTreeEchoStatus(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return replayed, nil
+}
+
+// Subscribe returns a channel that receives events appended to the
+// engine's event log from this point forward. Events are dropped for a
+// subscriber that falls more than 32 events behind. The channel is closed
+// when ctx is cancelled or the event log is closed.
+func (e *Engine) Subscribe(ctx context.Context) (<-chan Event, error) {
+	e.mu.RLock()
+	log := e.eventLog
+	e.mu.RUnlock()
+	if log == nil {
+		return nil, fmt.Errorf("orchestration: no event log attached")
+	}
+
+	fileLog, ok := log.(*FileEventLog)
+	if !ok {
+		return nil, fmt.Errorf("orchestration: event log backend does not support subscriptions")
+	}
+
+	sub := fileLog.subscribe()
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+		defer fileLog.unsubscribe(sub)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-sub:
+				if !ok {
+					return
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
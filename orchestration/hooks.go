@@ -0,0 +1,235 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HookVerdict is the outcome reported by a single hook stage.
+type HookVerdict string
+
+const (
+	HookVerdictPass     HookVerdict = "pass"
+	HookVerdictFail     HookVerdict = "fail"
+	HookVerdictAdvisory HookVerdict = "advisory"
+)
+
+// HookKind identifies what a Hook reference points at.
+type HookKind string
+
+const (
+	HookKindTask HookKind = "task" // a reflect Task with this ID, run on a throwaway engine
+	HookKindTool HookKind = "tool" // a registered tool, by name
+	HookKindHTTP HookKind = "http" // an external callback URL
+)
+
+// Hook is a single pre- or post-task check. A Mandatory pre-hook that
+// fails short-circuits the task; a Mandatory post-hook that fails vetoes
+// storing the task's output in agent memory.
+type Hook struct {
+	Name      string
+	Kind      HookKind
+	Ref       string // task ID, tool name, or URL, depending on Kind
+	Mandatory bool
+}
+
+// HookResult records the outcome of running a single Hook.
+type HookResult struct {
+	Name     string
+	Verdict  HookVerdict
+	Message  string
+	Duration time.Duration
+}
+
+// hookError signals that a mandatory pre-hook failed, short-circuiting
+// the task it guards.
+type hookError struct {
+	result HookResult
+}
+
+func (e *hookError) Error() string {
+	return fmt.Sprintf("orchestration: mandatory hook %q failed: %s", e.result.Name, e.result.Message)
+}
+
+// HTTPDoer is the subset of *http.Client used to invoke HookKindHTTP
+// hooks, so callers can substitute a test double.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HookExecutor runs Hook declarations. The engine's default executor
+// handles HookKindTask and HookKindTool against its own registries and
+// HookKindHTTP via httpClient; callers needing custom policy evaluation
+// can install their own HookExecutor on the engine.
+type HookExecutor interface {
+	RunHook(ctx context.Context, hook Hook, task *Task, result *TaskResult) HookResult
+}
+
+type defaultHookExecutor struct {
+	engine     *Engine
+	httpClient HTTPDoer
+}
+
+func (d *defaultHookExecutor) RunHook(ctx context.Context, hook Hook, task *Task, result *TaskResult) HookResult {
+	started := time.Now()
+
+	switch hook.Kind {
+	case HookKindTask:
+		d.engine.mu.RLock()
+		agent, ok := d.engine.agents[task.AgentID]
+		d.engine.mu.RUnlock()
+		if !ok {
+			return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: "unknown agent for hook task", Duration: time.Since(started)}
+		}
+
+		// Run the hook task against a throwaway engine and a copy of the
+		// agent, not the guarded agent itself: a gating check shouldn't
+		// pollute the real agent's memory, bump dte.ThoughtCount, or emit
+		// a task_executed event every time it runs.
+		shadow := NewEngine(d.engine.client)
+		shadowAgent := &Agent{ID: agent.ID, Name: agent.Name, Type: agent.Type}
+		hookTask := &Task{ID: hook.Ref, Type: TaskTypeReflect, Input: task.Input, Status: TaskStatusPending, AgentID: agent.ID}
+		if _, err := shadow.ExecuteTask(ctx, hookTask, shadowAgent); err != nil {
+			return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: err.Error(), Duration: time.Since(started)}
+		}
+		return HookResult{Name: hook.Name, Verdict: HookVerdictPass, Message: "hook task completed", Duration: time.Since(started)}
+
+	case HookKindTool:
+		d.engine.mu.RLock()
+		_, ok := d.engine.tools[hook.Ref]
+		d.engine.mu.RUnlock()
+		if !ok {
+			return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: fmt.Sprintf("unknown tool %q", hook.Ref), Duration: time.Since(started)}
+		}
+		return HookResult{Name: hook.Name, Verdict: HookVerdictPass, Message: fmt.Sprintf("tool %q available", hook.Ref), Duration: time.Since(started)}
+
+	case HookKindHTTP:
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Ref, nil)
+		if err != nil {
+			return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: err.Error(), Duration: time.Since(started)}
+		}
+		resp, err := d.httpClient.Do(req)
+		if err != nil {
+			return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: err.Error(), Duration: time.Since(started)}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: fmt.Sprintf("callback returned %d", resp.StatusCode), Duration: time.Since(started)}
+		}
+		return HookResult{Name: hook.Name, Verdict: HookVerdictPass, Message: fmt.Sprintf("callback returned %d", resp.StatusCode), Duration: time.Since(started)}
+
+	default:
+		return HookResult{Name: hook.Name, Verdict: HookVerdictFail, Message: fmt.Sprintf("unknown hook kind %q", hook.Kind), Duration: time.Since(started)}
+	}
+}
+
+// HookedTask pairs a Task with the hook stages that should gate its
+// execution.
+type HookedTask struct {
+	Task
+	PreHooks  []Hook
+	PostHooks []Hook
+}
+
+// ExecuteTaskWithHooks runs task.PreHooks, then the task body via
+// ExecuteTask, then task.PostHooks, recording every stage's verdict on
+// the returned TaskResult.HookResults. A failing Mandatory pre-hook
+// short-circuits the task body; a failing Mandatory post-hook vetoes
+// storing the output in agent memory.
+//
+// This is a deliberate separate entry point rather than a change to
+// ExecuteTask itself: Task has no Hooks field, so every existing caller
+// of ExecuteTask (the demo included) keeps running ungated, and callers
+// that want policy gating opt in by wrapping their Task in a HookedTask
+// and calling this method instead.
+func (e *Engine) ExecuteTaskWithHooks(ctx context.Context, task *HookedTask, agent *Agent) (*TaskResult, error) {
+	executor := e.hookExecutor()
+
+	var hookResults []HookResult
+	for _, hook := range task.PreHooks {
+		hr := executor.RunHook(ctx, hook, &task.Task, nil)
+		hookResults = append(hookResults, hr)
+		if err := e.recordHookVerdict(ctx, task.AgentID, hr); err != nil {
+			return nil, err
+		}
+		if hr.Verdict == HookVerdictFail && hook.Mandatory {
+			return &TaskResult{
+				TaskID:      task.ID,
+				Status:      TaskStatusFailed,
+				HookResults: hookResults,
+				Started:     time.Now(),
+				Finished:    time.Now(),
+			}, &hookError{result: hr}
+		}
+	}
+
+	e.mu.RLock()
+	preMemoryLen := len(agent.State.Memory)
+	e.mu.RUnlock()
+
+	result, err := e.ExecuteTask(ctx, &task.Task, agent)
+	if err != nil {
+		result = &TaskResult{TaskID: task.ID, Status: TaskStatusFailed}
+	}
+	result.HookResults = hookResults
+
+	vetoed := false
+	for _, hook := range task.PostHooks {
+		hr := executor.RunHook(ctx, hook, &task.Task, result)
+		result.HookResults = append(result.HookResults, hr)
+		if recordErr := e.recordHookVerdict(ctx, task.AgentID, hr); recordErr != nil && err == nil {
+			err = recordErr
+		}
+		if hr.Verdict == HookVerdictFail && hook.Mandatory {
+			vetoed = true
+		}
+	}
+
+	if vetoed {
+		e.mu.Lock()
+		if len(agent.State.Memory) > preMemoryLen {
+			agent.State.Memory = agent.State.Memory[:preMemoryLen]
+		}
+		e.mu.Unlock()
+	}
+
+	return result, err
+}
+
+// recordHookVerdict appends a HookVerdict event to the engine's event log,
+// if one is attached, so hook outcomes are replayable alongside task
+// executions and DTE status refreshes.
+func (e *Engine) recordHookVerdict(ctx context.Context, agentID string, hr HookResult) error {
+	e.mu.RLock()
+	log := e.eventLog
+	e.mu.RUnlock()
+	if log == nil {
+		return nil
+	}
+	if _, err := log.Append(ctx, EventTypeHookVerdict, agentID, hr); err != nil {
+		return fmt.Errorf("orchestration: recording hook verdict: %w", err)
+	}
+	return nil
+}
+
+// hookExecutor returns the engine's configured HookExecutor, defaulting
+// to one backed by the engine's own agent/tool registries and http.DefaultClient.
+func (e *Engine) hookExecutor() HookExecutor {
+	e.mu.RLock()
+	executor := e.hooks
+	e.mu.RUnlock()
+	if executor != nil {
+		return executor
+	}
+	return &defaultHookExecutor{engine: e, httpClient: http.DefaultClient}
+}
+
+// SetHookExecutor installs a custom HookExecutor, overriding the
+// engine's default task/tool/HTTP hook handling.
+func (e *Engine) SetHookExecutor(executor HookExecutor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.hooks = executor
+}
@@ -0,0 +1,117 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// introspectionIgnoreDirs are skipped entirely when walking the repository
+// root during introspection.
+var introspectionIgnoreDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+}
+
+// PerformDeepTreeEchoIntrospection walks root, scores each file's
+// salience, and folds files above minSalience into the Deep Tree Echo
+// hypergraph. coverageThreshold is reserved for filtering by how much of
+// the tree has already been covered by prior passes.
+func (e *Engine) PerformDeepTreeEchoIntrospection(ctx context.Context, root string, minSalience, coverageThreshold float64) (*IntrospectionResult, error) {
+	var processed, filtered int
+	var salient []SalientFile
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if info.IsDir() {
+			if introspectionIgnoreDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		processed++
+		salience := fileSalience(path, info)
+		if salience < minSalience {
+			filtered++
+			return nil
+		}
+		salient = append(salient, SalientFile{Path: path, Salience: salience})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(salient, func(i, j int) bool { return salient[i].Salience > salient[j].Salience })
+
+	e.mu.Lock()
+	if e.dte != nil {
+		e.dte.RecursiveDepth++
+	}
+	log := e.eventLog
+	e.mu.Unlock()
+
+	snapshot := CognitiveSnapshot{
+		ProcessedFiles:     processed,
+		FilteredFiles:      filtered,
+		AttentionThreshold: minSalience,
+		SalientFiles:       salient,
+	}
+
+	if log != nil {
+		if _, err := log.Append(ctx, EventTypeIntrospectionSnapshot, "", snapshot); err != nil {
+			return nil, fmt.Errorf("orchestration: recording introspection snapshot: %w", err)
+		}
+	}
+
+	return &IntrospectionResult{
+		CognitiveSnapshot: snapshot,
+		EchoIntegration: EchoIntegration{
+			NodesCreated: len(salient),
+			TreeDepth:    e.treeDepth(),
+		},
+	}, nil
+}
+
+// treeDepth reports the current Deep Tree Echo recursive depth, or 1 if
+// the system has not been initialized.
+func (e *Engine) treeDepth() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.dte == nil {
+		return 1
+	}
+	return e.dte.RecursiveDepth
+}
+
+// fileSalience scores how strongly a file should draw introspection
+// attention: source and architecture-bearing files rank highest, then
+// documentation, then everything else.
+func fileSalience(path string, info os.FileInfo) float64 {
+	ext := strings.ToLower(filepath.Ext(path))
+	base := strings.ToLower(filepath.Base(path))
+
+	switch {
+	case base == "go.mod" || base == "cargo.toml" || base == "package.json":
+		return 0.95
+	case ext == ".go" || ext == ".rs" || ext == ".py" || ext == ".ts":
+		return 0.8
+	case ext == ".md":
+		return 0.5
+	case ext == ".yaml" || ext == ".yml" || ext == ".json":
+		return 0.4
+	default:
+		return 0.1
+	}
+}
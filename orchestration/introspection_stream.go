@@ -0,0 +1,280 @@
+package orchestration
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// IntrospectionEventType identifies the kind of event emitted by
+// PerformDeepTreeEchoIntrospectionStream.
+type IntrospectionEventType string
+
+const (
+	EventFileScanned           IntrospectionEventType = "file_scanned"
+	EventSalienceComputed      IntrospectionEventType = "salience_computed"
+	EventNodeCreated           IntrospectionEventType = "node_created"
+	EventAttentionRecalibrated IntrospectionEventType = "attention_recalibrated"
+	EventPhaseCompleted        IntrospectionEventType = "phase_completed"
+)
+
+// IntrospectionEvent is a single typed progress update from a streaming
+// introspection scan.
+type IntrospectionEvent struct {
+	Type  IntrospectionEventType
+	Path  string
+	File  SalientFile
+	Phase string
+	Time  time.Time
+}
+
+// IntrospectionMetrics is a point-in-time snapshot of scan throughput,
+// emitted on the metrics channel on a fixed tick.
+type IntrospectionMetrics struct {
+	FilesPerSecond float64
+	FilteredCount  int
+	AvgSalience    float64
+	HeapDepth      int
+	Time           time.Time
+}
+
+// introspectionChunk is a contiguous range of a file list assigned to one
+// worker, mirroring the ct-sans collector's file-range chunking.
+type introspectionChunk struct {
+	files []string
+}
+
+// salienceHeap is a min-heap of SalientFile ordered by Salience, used to
+// keep only the top-K most salient files seen so far.
+type salienceHeap []SalientFile
+
+func (h salienceHeap) Len() int            { return len(h) }
+func (h salienceHeap) Less(i, j int) bool  { return h[i].Salience < h[j].Salience }
+func (h salienceHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *salienceHeap) Push(x interface{}) { *h = append(*h, x.(SalientFile)) }
+func (h *salienceHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// PerformDeepTreeEchoIntrospectionStream is the streaming counterpart to
+// PerformDeepTreeEchoIntrospection. It partitions the files under root
+// into chunks processed concurrently by a bounded worker pool, merges
+// files at or above thresholds.AttentionThreshold into a min-heap keyed by
+// salience that keeps the topK most salient of them (all of them when
+// topK <= 0), and emits typed progress events and periodic throughput
+// metrics until the scan completes or ctx is cancelled. The heap's
+// contents are not sent on out or metrics while the scan runs: metrics
+// carry only its size (HeapDepth), and the kept files are recorded only
+// in the final introspection snapshot, when an event log is attached.
+//
+// Both out and metrics are closed before this method returns, whether it
+// finishes the scan or is cancelled.
+func (e *Engine) PerformDeepTreeEchoIntrospectionStream(ctx context.Context, root string, thresholds CognitiveSnapshot, topK int, out chan<- IntrospectionEvent, metrics chan<- IntrospectionMetrics) error {
+	defer close(out)
+	defer close(metrics)
+
+	emit := func(ev IntrospectionEvent) bool {
+		ev.Time = time.Now()
+		select {
+		case out <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if introspectionIgnoreDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	workers := runtime.NumCPU()
+	if workers < 1 {
+		workers = 1
+	}
+	chunkSize := (len(files) + workers - 1) / workers
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	var chunks []introspectionChunk
+	for start := 0; start < len(files); start += chunkSize {
+		end := start + chunkSize
+		if end > len(files) {
+			end = len(files)
+		}
+		chunks = append(chunks, introspectionChunk{files: files[start:end]})
+	}
+
+	var (
+		mu            sync.Mutex
+		h             salienceHeap
+		processed     int
+		filtered      int
+		salienceTotal float64
+		tickProcessed int
+	)
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	tickDone := make(chan struct{})
+	tickerExited := make(chan struct{})
+	go func() {
+		defer close(tickerExited)
+		lastTick := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tickDone:
+				return
+			case now := <-ticker.C:
+				mu.Lock()
+				elapsed := now.Sub(lastTick).Seconds()
+				rate := 0.0
+				if elapsed > 0 {
+					rate = float64(tickProcessed) / elapsed
+				}
+				avg := 0.0
+				if processed > 0 {
+					avg = salienceTotal / float64(processed)
+				}
+				m := IntrospectionMetrics{
+					FilesPerSecond: rate,
+					FilteredCount:  filtered,
+					AvgSalience:    avg,
+					HeapDepth:      h.Len(),
+					Time:           now,
+				}
+				tickProcessed = 0
+				lastTick = now
+				mu.Unlock()
+
+				select {
+				case metrics <- m:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	cancelled := false
+
+	for _, chunk := range chunks {
+		if ctx.Err() != nil {
+			cancelled = true
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(chunk introspectionChunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			for _, path := range chunk.files {
+				if ctx.Err() != nil {
+					return
+				}
+
+				info, err := os.Stat(path)
+				if err != nil {
+					continue
+				}
+
+				if !emit(IntrospectionEvent{Type: EventFileScanned, Path: path}) {
+					return
+				}
+
+				salience := fileSalience(path, info)
+
+				mu.Lock()
+				processed++
+				tickProcessed++
+				salienceTotal += salience
+				mu.Unlock()
+
+				if !emit(IntrospectionEvent{Type: EventSalienceComputed, Path: path, File: SalientFile{Path: path, Salience: salience}}) {
+					return
+				}
+
+				if salience < thresholds.AttentionThreshold {
+					mu.Lock()
+					filtered++
+					mu.Unlock()
+					continue
+				}
+
+				mu.Lock()
+				heap.Push(&h, SalientFile{Path: path, Salience: salience})
+				for topK > 0 && h.Len() > topK {
+					heap.Pop(&h)
+				}
+				mu.Unlock()
+
+				if !emit(IntrospectionEvent{Type: EventNodeCreated, Path: path, File: SalientFile{Path: path, Salience: salience}}) {
+					return
+				}
+			}
+		}(chunk)
+	}
+
+	wg.Wait()
+	close(tickDone)
+	<-tickerExited
+
+	if cancelled || ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	emit(IntrospectionEvent{Type: EventAttentionRecalibrated, Phase: "scan"})
+
+	e.mu.Lock()
+	if e.dte != nil {
+		e.dte.RecursiveDepth++
+	}
+	log := e.eventLog
+	e.mu.Unlock()
+
+	if log != nil {
+		mu.Lock()
+		topFiles := make([]SalientFile, len(h))
+		copy(topFiles, h)
+		snapshot := CognitiveSnapshot{
+			ProcessedFiles:     processed,
+			FilteredFiles:      filtered,
+			AttentionThreshold: thresholds.AttentionThreshold,
+			SalientFiles:       topFiles,
+		}
+		mu.Unlock()
+		if _, err := log.Append(ctx, EventTypeIntrospectionSnapshot, "", snapshot); err != nil {
+			return fmt.Errorf("orchestration: recording introspection snapshot: %w", err)
+		}
+	}
+
+	emit(IntrospectionEvent{Type: EventPhaseCompleted, Phase: "scan"})
+	return nil
+}
@@ -0,0 +1,449 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParamSpec describes one parameter accepted by a template, including its
+// default value when the caller omits it.
+type ParamSpec struct {
+	Name     string      `json:"name" yaml:"name"`
+	Default  interface{} `json:"default,omitempty" yaml:"default,omitempty"`
+	Required bool        `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// AgentTemplate is a declarative recipe for CreateSpecializedAgent-style
+// agents. Extends, when set, names another agent template whose Params
+// and Parameters this template inherits and may override.
+type AgentTemplate struct {
+	Name       string                 `json:"name" yaml:"name"`
+	Extends    string                 `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Type       AgentType              `json:"type,omitempty" yaml:"type,omitempty"`
+	Params     []ParamSpec            `json:"params,omitempty" yaml:"params,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// TaskTemplate is a declarative recipe for Task instances.
+type TaskTemplate struct {
+	Name       string                 `json:"name" yaml:"name"`
+	Extends    string                 `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Type       TaskType               `json:"type,omitempty" yaml:"type,omitempty"`
+	Input      string                 `json:"input,omitempty" yaml:"input,omitempty"`
+	Params     []ParamSpec            `json:"params,omitempty" yaml:"params,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// templateFile is the on-disk shape of a single template document; each
+// file may declare an agent template, a task template, or both.
+type templateFile struct {
+	Agent *AgentTemplate `json:"agent,omitempty" yaml:"agent,omitempty"`
+	Task  *TaskTemplate  `json:"task,omitempty" yaml:"task,omitempty"`
+}
+
+// TemplateRepo loads agent and task templates from a directory (or any
+// fs.FS, including an embedded one) and renders concrete Task/Agent
+// instances from them.
+type TemplateRepo struct {
+	fsys fs.FS
+	dir  string // root within fsys that templates are loaded from
+
+	mu          sync.RWMutex
+	agentTpls   map[string]AgentTemplate
+	taskTpls    map[string]TaskTemplate
+	stopWatch   chan struct{}
+	watchClosed bool
+}
+
+// NewTemplateRepo loads every *.yaml, *.yml and *.json file under dir in
+// fsys, validates them, and resolves template extension chains.
+func NewTemplateRepo(fsys fs.FS, dir string) (*TemplateRepo, error) {
+	repo := &TemplateRepo{
+		fsys:      fsys,
+		dir:       dir,
+		agentTpls: make(map[string]AgentTemplate),
+		taskTpls:  make(map[string]TaskTemplate),
+	}
+	if err := repo.load(); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// load reads every template file under dir and replaces the repo's
+// in-memory template sets.
+func (r *TemplateRepo) load() error {
+	agentTpls := make(map[string]AgentTemplate)
+	taskTpls := make(map[string]TaskTemplate)
+
+	err := fs.WalkDir(r.fsys, r.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			return nil
+		}
+
+		data, err := fs.ReadFile(r.fsys, path)
+		if err != nil {
+			return fmt.Errorf("orchestration: reading template %s: %w", path, err)
+		}
+
+		var tf templateFile
+		if ext == ".json" {
+			err = json.Unmarshal(data, &tf)
+		} else {
+			err = yaml.Unmarshal(data, &tf)
+		}
+		if err != nil {
+			return fmt.Errorf("orchestration: parsing template %s: %w", path, err)
+		}
+
+		if tf.Agent != nil {
+			if tf.Agent.Name == "" {
+				return fmt.Errorf("orchestration: template %s: agent template missing name", path)
+			}
+			agentTpls[tf.Agent.Name] = *tf.Agent
+		}
+		if tf.Task != nil {
+			if tf.Task.Name == "" {
+				return fmt.Errorf("orchestration: template %s: task template missing name", path)
+			}
+			taskTpls[tf.Task.Name] = *tf.Task
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for name := range agentTpls {
+		if _, err := resolveAgentTemplate(agentTpls, name, nil); err != nil {
+			return err
+		}
+	}
+	for name := range taskTpls {
+		if _, err := resolveTaskTemplate(taskTpls, name, nil); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	r.agentTpls = agentTpls
+	r.taskTpls = taskTpls
+	r.mu.Unlock()
+	return nil
+}
+
+// resolveAgentTemplate flattens a template's Extends chain into a single
+// effective template, with child fields overriding ancestor fields.
+func resolveAgentTemplate(all map[string]AgentTemplate, name string, seen []string) (AgentTemplate, error) {
+	for _, s := range seen {
+		if s == name {
+			return AgentTemplate{}, fmt.Errorf("orchestration: agent template cycle: %v", append(seen, name))
+		}
+	}
+	tpl, ok := all[name]
+	if !ok {
+		return AgentTemplate{}, fmt.Errorf("orchestration: unknown agent template %q", name)
+	}
+	if tpl.Extends == "" {
+		return tpl, nil
+	}
+
+	base, err := resolveAgentTemplate(all, tpl.Extends, append(seen, name))
+	if err != nil {
+		return AgentTemplate{}, err
+	}
+
+	merged := base
+	merged.Name = tpl.Name
+	merged.Extends = ""
+	if tpl.Type != "" {
+		merged.Type = tpl.Type
+	}
+	merged.Params = mergeParams(base.Params, tpl.Params)
+	merged.Parameters = mergeParameters(base.Parameters, tpl.Parameters)
+	return merged, nil
+}
+
+// resolveTaskTemplate flattens a task template's Extends chain, mirroring
+// resolveAgentTemplate.
+func resolveTaskTemplate(all map[string]TaskTemplate, name string, seen []string) (TaskTemplate, error) {
+	for _, s := range seen {
+		if s == name {
+			return TaskTemplate{}, fmt.Errorf("orchestration: task template cycle: %v", append(seen, name))
+		}
+	}
+	tpl, ok := all[name]
+	if !ok {
+		return TaskTemplate{}, fmt.Errorf("orchestration: unknown task template %q", name)
+	}
+	if tpl.Extends == "" {
+		return tpl, nil
+	}
+
+	base, err := resolveTaskTemplate(all, tpl.Extends, append(seen, name))
+	if err != nil {
+		return TaskTemplate{}, err
+	}
+
+	merged := base
+	merged.Name = tpl.Name
+	merged.Extends = ""
+	if tpl.Type != "" {
+		merged.Type = tpl.Type
+	}
+	if tpl.Input != "" {
+		merged.Input = tpl.Input
+	}
+	merged.Params = mergeParams(base.Params, tpl.Params)
+	merged.Parameters = mergeParameters(base.Parameters, tpl.Parameters)
+	return merged, nil
+}
+
+func mergeParams(base, override []ParamSpec) []ParamSpec {
+	merged := make(map[string]ParamSpec, len(base)+len(override))
+	order := make([]string, 0, len(base)+len(override))
+	for _, p := range base {
+		merged[p.Name] = p
+		order = append(order, p.Name)
+	}
+	for _, p := range override {
+		if _, exists := merged[p.Name]; !exists {
+			order = append(order, p.Name)
+		}
+		merged[p.Name] = p
+	}
+	result := make([]ParamSpec, 0, len(order))
+	for _, name := range order {
+		result = append(result, merged[name])
+	}
+	return result
+}
+
+func mergeParameters(base, override map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// InstantiateAgentTemplate renders a concrete Agent from the named
+// template, applying overrides on top of the template's parameter
+// defaults, registers it with the engine exactly as CreateSpecializedAgent
+// would, and stores the rendered parameters on Agent.Parameters so depth
+// limits, plugin params and prompt knobs declared on the template reach
+// the instantiated agent.
+func (e *Engine) InstantiateAgentTemplate(ctx context.Context, repo *TemplateRepo, name string, overrides map[string]interface{}) (*Agent, error) {
+	repo.mu.RLock()
+	_, ok := repo.agentTpls[name]
+	repo.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("orchestration: unknown agent template %q", name)
+	}
+
+	repo.mu.RLock()
+	resolved, err := resolveAgentTemplate(repo.agentTpls, name, nil)
+	repo.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := applyParams(resolved.Params, resolved.Parameters, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	agentName := name
+	if display, ok := params["display_name"].(string); ok && display != "" {
+		agentName = display
+	}
+
+	agent, err := e.CreateSpecializedAgent(ctx, resolved.Type, agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	agent.Parameters = params
+	e.mu.Unlock()
+
+	return agent, nil
+}
+
+// InstantiateTaskTemplate renders a concrete Task from the named
+// template, applying params on top of the template's parameter defaults.
+func (e *Engine) InstantiateTaskTemplate(ctx context.Context, repo *TemplateRepo, name string, params map[string]interface{}) (*Task, error) {
+	repo.mu.RLock()
+	_, ok := repo.taskTpls[name]
+	repo.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("orchestration: unknown task template %q", name)
+	}
+
+	repo.mu.RLock()
+	resolved, err := resolveTaskTemplate(repo.taskTpls, name, nil)
+	repo.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedParams, err := applyParams(resolved.Params, resolved.Parameters, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Task{
+		ID:         name,
+		Type:       resolved.Type,
+		Input:      resolved.Input,
+		Status:     TaskStatusPending,
+		Parameters: resolvedParams,
+	}, nil
+}
+
+// applyParams layers template param defaults, then the template's static
+// Parameters, then caller-supplied overrides, and fails if a required
+// param is left unset.
+func applyParams(specs []ParamSpec, base map[string]interface{}, overrides map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(base)+len(overrides))
+	for k, v := range base {
+		result[k] = v
+	}
+	for _, spec := range specs {
+		if _, set := result[spec.Name]; !set && spec.Default != nil {
+			result[spec.Name] = spec.Default
+		}
+	}
+	for k, v := range overrides {
+		result[k] = v
+	}
+	for _, spec := range specs {
+		if spec.Required {
+			if _, set := result[spec.Name]; !set {
+				return nil, fmt.Errorf("orchestration: missing required param %q", spec.Name)
+			}
+		}
+	}
+	return result, nil
+}
+
+// templateDirSnapshot maps each template file under dir to its modtime, so
+// successive snapshots can be diffed to detect adds, removes and edits.
+func (r *TemplateRepo) templateDirSnapshot() (map[string]time.Time, error) {
+	snap := make(map[string]time.Time)
+	err := fs.WalkDir(r.fsys, r.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		snap[path] = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func snapshotsDiffer(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	for path, modTime := range a {
+		if other, ok := b[path]; !ok || !other.Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// WatchAndReload watches the repo's backing directory for changes, by
+// polling at the given interval and diffing each template file's modtime
+// against the previous poll, and reloads templates only when a file was
+// added, removed or modified. It only supports OS-backed directories
+// (NewTemplateRepo called with os.DirFS): an fs.FS that reports a constant
+// ModTime, such as an embedded FS, will never be seen as changed. Call the
+// returned stop function to end watching.
+func (r *TemplateRepo) WatchAndReload(interval func() <-chan struct{}, onReload func(error)) (stop func()) {
+	r.mu.Lock()
+	r.stopWatch = make(chan struct{})
+	stopCh := r.stopWatch
+	r.mu.Unlock()
+
+	go func() {
+		ticks := interval()
+		lastSnapshot, _ := r.templateDirSnapshot()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case _, ok := <-ticks:
+				if !ok {
+					return
+				}
+				snapshot, err := r.templateDirSnapshot()
+				if err != nil {
+					if onReload != nil {
+						onReload(err)
+					}
+					continue
+				}
+				if !snapshotsDiffer(lastSnapshot, snapshot) {
+					continue
+				}
+				lastSnapshot = snapshot
+				err = r.load()
+				if onReload != nil {
+					onReload(err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if !r.watchClosed {
+			close(r.stopWatch)
+			r.watchClosed = true
+		}
+	}
+}
+
+// DirFS is a convenience wrapper around os.DirFS for the common case of
+// loading templates straight from a directory on disk.
+func DirFS(dir string) fs.FS {
+	return os.DirFS(dir)
+}
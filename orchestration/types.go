@@ -0,0 +1,154 @@
+package orchestration
+
+import "time"
+
+// TaskType identifies the kind of work a Task performs.
+type TaskType string
+
+const (
+	TaskTypeReflect  TaskType = "reflect"
+	TaskTypePlugin   TaskType = "plugin"
+	TaskTypeTool     TaskType = "tool"
+	TaskTypeGenerate TaskType = "generate"
+)
+
+// TaskStatus tracks a Task through its execution lifecycle.
+type TaskStatus string
+
+const (
+	TaskStatusPending   TaskStatus = "pending"
+	TaskStatusRunning   TaskStatus = "running"
+	TaskStatusCompleted TaskStatus = "completed"
+	TaskStatusFailed    TaskStatus = "failed"
+	TaskStatusSkipped   TaskStatus = "skipped"
+)
+
+// Task describes a single unit of work to be executed by an Agent.
+type Task struct {
+	ID         string
+	Type       TaskType
+	Input      string
+	Status     TaskStatus
+	AgentID    string
+	Parameters map[string]interface{}
+}
+
+// TaskResult carries the outcome of executing a Task.
+type TaskResult struct {
+	TaskID      string
+	Output      string
+	Status      TaskStatus
+	HookResults []HookResult
+	Started     time.Time
+	Finished    time.Time
+}
+
+// AgentType identifies the behavioral specialization of an Agent.
+type AgentType string
+
+const (
+	AgentTypeReflective   AgentType = "reflective"
+	AgentTypeOrchestrator AgentType = "orchestrator"
+	AgentTypeSpecialist   AgentType = "specialist"
+)
+
+// State holds the evolving memory of an Agent across interactions.
+type State struct {
+	Context         []string
+	Memory          []string
+	LastInteraction time.Time
+}
+
+// Agent is an individually addressable actor within the orchestration engine.
+type Agent struct {
+	ID    string
+	Name  string
+	Type  AgentType
+	State State
+
+	// Parameters holds the rendered parameter set an agent was created
+	// with, e.g. via InstantiateAgentTemplate (depth limits, plugin
+	// params, prompt knobs). Empty for agents created directly through
+	// CreateSpecializedAgent.
+	Parameters map[string]interface{}
+}
+
+// TestResult is a single diagnostic check performed against the engine.
+type TestResult struct {
+	Name    string
+	Status  string // "pass", "warn", "fail"
+	Message string
+}
+
+// DiagnosticResult summarizes the outcome of RunDeepTreeEchoDiagnostics.
+type DiagnosticResult struct {
+	OverallHealth string
+	Timestamp     time.Time
+	Tests         []TestResult
+}
+
+// SalientFile is a repository file surfaced by introspection, ranked by
+// how strongly it drew attention during the scan.
+type SalientFile struct {
+	Path     string
+	Salience float64
+}
+
+// CognitiveSnapshot captures the state of a single introspection pass.
+type CognitiveSnapshot struct {
+	ProcessedFiles     int
+	FilteredFiles      int
+	AttentionThreshold float64
+	SalientFiles       []SalientFile
+}
+
+// EchoIntegration reports how an introspection pass was folded into the
+// Deep Tree Echo hypergraph.
+type EchoIntegration struct {
+	NodesCreated int
+	TreeDepth    int
+}
+
+// IntrospectionResult is the outcome of a recursive self-introspection pass.
+type IntrospectionResult struct {
+	CognitiveSnapshot CognitiveSnapshot
+	EchoIntegration   EchoIntegration
+}
+
+// IdentityCoherence tracks how consistent the agent's self-model is across
+// introspection passes.
+type IdentityCoherence struct {
+	OverallCoherence float64
+}
+
+// MemoryResonance summarizes the hypergraph backing Deep Tree Echo memory.
+type MemoryResonance struct {
+	MemoryNodes int
+	Connections int
+}
+
+// EvolutionTimeline tracks the Deep Tree Echo system's developmental stage.
+type EvolutionTimeline struct {
+	CurrentStage string
+}
+
+// IntegrationStatus reports the health of an external system Deep Tree Echo
+// is wired into (tool backends, plugins, memory stores, ...).
+type IntegrationStatus struct {
+	Status string // "connected", "degraded", "disconnected"
+	Health string
+}
+
+// DeepTreeEcho is the engine's cognitive architecture state: identity
+// coherence, memory resonance, and evolution tracking layered on top of
+// ordinary task execution.
+type DeepTreeEcho struct {
+	SystemHealth      string
+	CoreStatus        string
+	ThoughtCount      int
+	RecursiveDepth    int
+	IdentityCoherence IdentityCoherence
+	MemoryResonance   MemoryResonance
+	EvolutionTimeline EvolutionTimeline
+	Integrations      map[string]IntegrationStatus
+}
@@ -0,0 +1,322 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// WorkflowTask is a single node in a Workflow DAG. It wraps the same Task
+// definition used by Engine.ExecuteTask, naming it so other nodes can
+// depend on it and reference its output.
+type WorkflowTask struct {
+	Name         string
+	Type         TaskType
+	Input        string
+	Arguments    map[string]interface{}
+	Dependencies []string
+
+	// ContinueOn allows downstream tasks to proceed even if this task
+	// fails, instead of cancelling the rest of the workflow.
+	ContinueOn bool
+}
+
+// Workflow is a named DAG of WorkflowTasks. Tasks run concurrently once
+// their dependencies are satisfied, up to Parallelism at a time.
+type Workflow struct {
+	Name  string
+	Tasks []WorkflowTask
+
+	// Parallelism caps how many tasks may run concurrently. Zero means
+	// unbounded.
+	Parallelism int
+}
+
+// WorkflowTaskResult is the outcome of a single WorkflowTask within a
+// workflow run.
+type WorkflowTaskResult struct {
+	Name   string
+	Result *TaskResult
+	Err    error
+	Status TaskStatus
+}
+
+// WorkflowResult is the combined outcome of running a Workflow.
+type WorkflowResult struct {
+	Tasks map[string]*WorkflowTaskResult
+}
+
+var outputRefPattern = regexp.MustCompile(`^\{\{tasks\.([^.}]+)\.output(?:\.([^}]+))?\}\}$`)
+
+// ExecuteWorkflow topologically sorts the workflow's tasks, runs
+// independent tasks concurrently up to workflow.Parallelism, substitutes
+// "{{tasks.<name>.output[.<field>]}}" references to upstream outputs into
+// each task's Arguments, and propagates cancellation when a task without
+// ContinueOn fails.
+//
+// If targets is non-empty, only those named tasks (and their transitive
+// dependencies) are executed.
+func (e *Engine) ExecuteWorkflow(ctx context.Context, workflow *Workflow, agent *Agent, targets ...string) (*WorkflowResult, error) {
+	if workflow == nil {
+		return nil, fmt.Errorf("orchestration: nil workflow")
+	}
+
+	byName := make(map[string]*WorkflowTask, len(workflow.Tasks))
+	for i := range workflow.Tasks {
+		t := &workflow.Tasks[i]
+		if _, dup := byName[t.Name]; dup {
+			return nil, fmt.Errorf("orchestration: duplicate workflow task %q", t.Name)
+		}
+		byName[t.Name] = t
+	}
+	for _, t := range workflow.Tasks {
+		for _, dep := range t.Dependencies {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("orchestration: task %q depends on unknown task %q", t.Name, dep)
+			}
+		}
+	}
+
+	selected, err := selectWithDependencies(byName, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := topologicalSort(byName, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism := workflow.Parallelism
+	if parallelism <= 0 {
+		parallelism = len(order)
+		if parallelism == 0 {
+			parallelism = 1
+		}
+	}
+	sem := make(chan struct{}, parallelism)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := &WorkflowResult{Tasks: make(map[string]*WorkflowTaskResult, len(order))}
+	var mu sync.Mutex
+	done := make(map[string]chan struct{}, len(order))
+	for _, name := range order {
+		done[name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range order {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer close(done[name])
+
+			task := byName[name]
+			for _, dep := range task.Dependencies {
+				select {
+				case <-done[dep]:
+				case <-runCtx.Done():
+					mu.Lock()
+					result.Tasks[name] = &WorkflowTaskResult{Name: name, Status: TaskStatusSkipped, Err: runCtx.Err()}
+					mu.Unlock()
+					return
+				}
+				mu.Lock()
+				depStatus := TaskStatus("")
+				if dr := result.Tasks[dep]; dr != nil {
+					depStatus = dr.Status
+				}
+				mu.Unlock()
+				depFailed := depStatus == TaskStatusFailed && !byName[dep].ContinueOn
+				if depStatus == TaskStatusSkipped || depFailed {
+					mu.Lock()
+					result.Tasks[name] = &WorkflowTaskResult{Name: name, Status: TaskStatusSkipped, Err: fmt.Errorf("orchestration: upstream task %q %s", dep, depStatus)}
+					mu.Unlock()
+					return
+				}
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-runCtx.Done():
+				mu.Lock()
+				result.Tasks[name] = &WorkflowTaskResult{Name: name, Status: TaskStatusSkipped, Err: runCtx.Err()}
+				mu.Unlock()
+				return
+			}
+
+			mu.Lock()
+			args, argErr := substituteArguments(task.Arguments, result.Tasks)
+			mu.Unlock()
+			if argErr != nil {
+				mu.Lock()
+				result.Tasks[name] = &WorkflowTaskResult{Name: name, Status: TaskStatusFailed, Err: argErr}
+				mu.Unlock()
+				if !task.ContinueOn {
+					cancel()
+				}
+				return
+			}
+
+			t := &Task{
+				ID:         name,
+				Type:       task.Type,
+				Input:      task.Input,
+				Status:     TaskStatusPending,
+				AgentID:    agent.ID,
+				Parameters: args,
+			}
+
+			taskResult, execErr := e.ExecuteTask(runCtx, t, agent)
+			status := TaskStatusCompleted
+			if execErr != nil {
+				status = TaskStatusFailed
+			}
+
+			mu.Lock()
+			result.Tasks[name] = &WorkflowTaskResult{Name: name, Result: taskResult, Err: execErr, Status: status}
+			mu.Unlock()
+
+			if execErr != nil && !task.ContinueOn {
+				cancel()
+			}
+		}(name)
+	}
+
+	wg.Wait()
+	return result, nil
+}
+
+// selectWithDependencies returns the set of task names reachable by
+// walking targets' dependency edges backwards. An empty targets selects
+// every task in the workflow.
+func selectWithDependencies(byName map[string]*WorkflowTask, targets []string) (map[string]bool, error) {
+	selected := make(map[string]bool, len(byName))
+	if len(targets) == 0 {
+		for name := range byName {
+			selected[name] = true
+		}
+		return selected, nil
+	}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if selected[name] {
+			return nil
+		}
+		task, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("orchestration: unknown target task %q", name)
+		}
+		selected[name] = true
+		for _, dep := range task.Dependencies {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, target := range targets {
+		if err := visit(target); err != nil {
+			return nil, err
+		}
+	}
+	return selected, nil
+}
+
+// topologicalSort orders the selected tasks so every task appears after
+// its dependencies, and rejects cycles.
+func topologicalSort(byName map[string]*WorkflowTask, selected map[string]bool) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(selected))
+	order := make([]string, 0, len(selected))
+
+	var visit func(name string, stack []string) error
+	visit = func(name string, stack []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("orchestration: dependency cycle detected: %v", append(stack, name))
+		}
+
+		task, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("orchestration: unknown dependency %q", name)
+		}
+
+		state[name] = visiting
+		for _, dep := range task.Dependencies {
+			if !selected[dep] {
+				continue
+			}
+			if err := visit(dep, append(stack, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for name := range selected {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// substituteArguments resolves "{{tasks.<name>.output[.<field>]}}"
+// placeholders in args against the outputs of already-completed tasks.
+func substituteArguments(args map[string]interface{}, results map[string]*WorkflowTaskResult) (map[string]interface{}, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+
+	resolved := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		str, ok := value.(string)
+		if !ok {
+			resolved[key] = value
+			continue
+		}
+
+		match := outputRefPattern.FindStringSubmatch(str)
+		if match == nil {
+			resolved[key] = value
+			continue
+		}
+
+		taskName, field := match[1], match[2]
+		upstream, ok := results[taskName]
+		if !ok || upstream.Result == nil {
+			return nil, fmt.Errorf("orchestration: argument %q references output of task %q, which has no result", key, taskName)
+		}
+
+		if field == "" {
+			resolved[key] = upstream.Result.Output
+			continue
+		}
+
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(upstream.Result.Output), &decoded); err != nil {
+			return nil, fmt.Errorf("orchestration: argument %q references field %q of task %q, but its output is not structured: %w", key, field, taskName, err)
+		}
+		fieldValue, ok := decoded[field]
+		if !ok {
+			return nil, fmt.Errorf("orchestration: task %q output has no field %q", taskName, field)
+		}
+		resolved[key] = fieldValue
+	}
+	return resolved, nil
+}